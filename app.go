@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	_ "github.com/lib/pq"
+
+	"github.com/36thchambersoftware/cardano-db-sync-api/cache"
+	"github.com/36thchambersoftware/cardano-db-sync-api/config"
+	"github.com/36thchambersoftware/cardano-db-sync-api/events"
+	"github.com/36thchambersoftware/cardano-db-sync-api/health"
+	"github.com/36thchambersoftware/cardano-db-sync-api/server"
+)
+
+// App owns the service's connections and HTTP server and manages their
+// startup and shutdown.
+type App struct {
+	cfg         *config.Config
+	conn        *sql.DB
+	redisClient *goredis.Client
+	cache       *cache.Cache
+	checker     *health.Checker
+	httpServer  *http.Server
+	publisher   *events.Publisher
+	stopPing    chan struct{}
+	stopEvents  context.CancelFunc
+}
+
+// NewApp opens the Postgres and Redis connections described by cfg. It does
+// not verify they're reachable; call Start for that.
+func NewApp(cfg *config.Config) (*App, error) {
+	// DB_URL carries its own sslmode, so DB_TLS_ENABLED is enforced by
+	// rejecting a URL that has explicitly turned TLS back off, rather than
+	// by the driver option sql.Open doesn't expose.
+	if cfg.Postgres.TLSEnabled && strings.Contains(cfg.Postgres.URL, "sslmode=disable") {
+		return nil, fmt.Errorf("app: DB_TLS_ENABLED is set but DB_URL has sslmode=disable")
+	}
+
+	conn, err := sql.Open("postgres", cfg.Postgres.URL)
+	if err != nil {
+		return nil, fmt.Errorf("app: open db: %w", err)
+	}
+	conn.SetMaxOpenConns(cfg.Postgres.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.Postgres.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.Postgres.ConnMaxLifetime)
+
+	redisOpts := &goredis.Options{
+		Addr:     cfg.Redis.Addr,
+		Username: cfg.Redis.Username,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+		PoolSize: cfg.Redis.PoolSize,
+	}
+	if cfg.Redis.TLSEnabled {
+		redisOpts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	redisClient := goredis.NewClient(redisOpts)
+
+	c := cache.New(redisClient)
+
+	return &App{
+		cfg:         cfg,
+		conn:        conn,
+		redisClient: redisClient,
+		cache:       c,
+		checker:     health.NewChecker(),
+		publisher:   events.NewPublisher(conn, redisClient, c),
+		stopPing:    make(chan struct{}),
+	}, nil
+}
+
+// Start pings Postgres and Redis, failing fast if either is unreachable,
+// binds the HTTP listener, and brings up request serving and the
+// background readiness prober. Sync lag does not gate startup: a node
+// that's still catching up should still boot, just report not-ready on
+// /readyz until probeLoop sees it within threshold.
+func (a *App) Start(ctx context.Context) error {
+	if err := a.conn.PingContext(ctx); err != nil {
+		return fmt.Errorf("app: postgres unreachable: %w", err)
+	}
+	a.checker.SetDB(true)
+
+	if err := a.redisClient.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("app: redis unreachable: %w", err)
+	}
+	a.checker.SetRedis(true)
+
+	a.checker.SetSyncWithinThreshold(a.checkSyncLag(ctx) == nil)
+
+	a.httpServer = &http.Server{
+		Handler: server.New(a.conn, a.redisClient, a.cache, a.checker),
+	}
+
+	listener, err := net.Listen("tcp", a.cfg.Server.Addr)
+	if err != nil {
+		return fmt.Errorf("app: listen on %s: %w", a.cfg.Server.Addr, err)
+	}
+
+	go func() {
+		if err := a.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("app: server stopped: %v", err)
+		}
+	}()
+
+	go a.probeLoop()
+
+	eventsCtx, cancel := context.WithCancel(context.Background())
+	a.stopEvents = cancel
+	go func() {
+		if err := a.publisher.Run(eventsCtx, a.cfg.Events.PollInterval); err != nil && err != context.Canceled {
+			log.Printf("app: event publisher stopped: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Shutdown stops the background prober, drains the HTTP server, and closes
+// the Postgres and Redis connections.
+func (a *App) Shutdown(ctx context.Context) error {
+	close(a.stopPing)
+
+	if a.stopEvents != nil {
+		a.stopEvents()
+	}
+
+	if a.httpServer != nil {
+		if err := a.httpServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("app: http shutdown: %w", err)
+		}
+	}
+
+	if err := a.redisClient.Close(); err != nil {
+		return fmt.Errorf("app: redis close: %w", err)
+	}
+
+	if err := a.conn.Close(); err != nil {
+		return fmt.Errorf("app: db close: %w", err)
+	}
+
+	return nil
+}
+
+// probeLoop periodically re-pings Postgres and Redis and re-checks sync
+// lag, flipping readiness state as conditions change.
+func (a *App) probeLoop() {
+	ticker := time.NewTicker(a.cfg.Server.ReadinessInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.stopPing:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ReadinessInterval)
+			a.checker.SetDB(a.conn.PingContext(ctx) == nil)
+			a.checker.SetRedis(a.redisClient.Ping(ctx).Err() == nil)
+			a.checker.SetSyncWithinThreshold(a.checkSyncLag(ctx) == nil)
+			cancel()
+		}
+	}
+}
+
+// checkSyncLag compares cardano-db-sync's latest indexed block time against
+// now, erroring if it's older than the configured threshold.
+func (a *App) checkSyncLag(ctx context.Context) error {
+	var lastBlockTime time.Time
+	row := a.conn.QueryRowContext(ctx, `SELECT time FROM block ORDER BY id DESC LIMIT 1`)
+	if err := row.Scan(&lastBlockTime); err != nil {
+		return fmt.Errorf("query latest block time: %w", err)
+	}
+
+	if lag := time.Since(lastBlockTime); lag > a.cfg.Server.SyncLagThreshold {
+		return fmt.Errorf("sync lag %s exceeds threshold %s", lag, a.cfg.Server.SyncLagThreshold)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// Package cache provides a Redis-backed read-through cache that sits in
+// front of the module's cardano-db-sync Postgres queries, cutting DB load on
+// hot, frequently-repeated reads.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+)
+
+// TTL presets for query classes. Tip/UTxO data changes every block; epoch
+// parameters and finalized block bodies are effectively immutable once
+// written.
+const (
+	TTLTip     = 5 * time.Second
+	TTLUTxO    = 5 * time.Second
+	TTLHistory = 24 * time.Hour
+)
+
+// Cache wraps a Redis client with read-through helpers and single-flight
+// de-duplication of concurrent misses.
+type Cache struct {
+	rdb   *redis.Client
+	group singleflight.Group
+}
+
+// New returns a Cache backed by rdb.
+func New(rdb *redis.Client) *Cache {
+	return &Cache{rdb: rdb}
+}
+
+// GetOrLoad returns the cached value for key if present, otherwise invokes
+// loader, stores its result under key for ttl, and returns it. Concurrent
+// calls for the same key collapse into a single loader invocation.
+func (c *Cache) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loader func() ([]byte, error)) ([]byte, error) {
+	if cached, err := c.rdb.Get(ctx, key).Bytes(); err == nil {
+		return cached, nil
+	} else if err != redis.Nil {
+		return nil, fmt.Errorf("cache: get %q: %w", key, err)
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		data, err := loader()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.rdb.Set(ctx, key, data, ttl).Err(); err != nil {
+			return nil, fmt.Errorf("cache: set %q: %w", key, err)
+		}
+
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]byte), nil
+}
+
+// GetOrLoadJSON is GetOrLoad with JSON encoding/decoding of dst.
+func (c *Cache) GetOrLoadJSON(ctx context.Context, key string, ttl time.Duration, dst interface{}, loader func() (interface{}, error)) error {
+	data, err := c.GetOrLoad(ctx, key, ttl, func() ([]byte, error) {
+		v, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, dst)
+}
+
+// Invalidate deletes every cached key under prefix. A chain-tip watcher
+// should call this on rollback so stale tip/UTxO reads aren't served from
+// cache after a reorg.
+func (c *Cache) Invalidate(ctx context.Context, prefix string) error {
+	iter := c.rdb.Scan(ctx, 0, prefix+"*", 0).Iterator()
+
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("cache: scan %q: %w", prefix, err)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := c.rdb.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("cache: del %q: %w", prefix, err)
+	}
+
+	return nil
+}
+
+// Key builds a cache key from a query name and its normalized arguments.
+func Key(query string, args ...string) string {
+	if len(args) == 0 {
+		return query
+	}
+	return query + ":" + strings.Join(args, ":")
+}
+
+type ctxKey int
+
+const cacheKey ctxKey = iota
+
+// Middleware attaches c to the request context of every request it
+// handles, so handlers can reach it via FromContext instead of a
+// package-level global.
+func Middleware(c *Cache) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), cacheKey, c)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the *Cache stashed by Middleware, if any.
+func FromContext(ctx context.Context) (*Cache, bool) {
+	c, ok := ctx.Value(cacheKey).(*Cache)
+	return c, ok
+}
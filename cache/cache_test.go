@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	return New(rdb)
+}
+
+func TestGetOrLoadCachesAfterFirstLoad(t *testing.T) {
+	c := newTestCache(t)
+	ctx := context.Background()
+
+	var loads int32
+	loader := func() ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		return []byte("value"), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		got, err := c.GetOrLoad(ctx, "key", time.Minute, loader)
+		if err != nil {
+			t.Fatalf("GetOrLoad() error = %v", err)
+		}
+		if string(got) != "value" {
+			t.Fatalf("GetOrLoad() = %q, want %q", got, "value")
+		}
+	}
+
+	if loads != 1 {
+		t.Errorf("loader invoked %d times, want 1 (the rest should be cache hits)", loads)
+	}
+}
+
+func TestGetOrLoadCollapsesConcurrentMisses(t *testing.T) {
+	c := newTestCache(t)
+	ctx := context.Background()
+
+	var loads int32
+	release := make(chan struct{})
+	loader := func() ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return []byte("value"), nil
+	}
+
+	const concurrency = 10
+	var wg sync.WaitGroup
+	errs := make([]error, concurrency)
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.GetOrLoad(ctx, "key", time.Minute, loader)
+		}()
+	}
+
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("GetOrLoad()[%d] error = %v", i, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loader invoked %d times for %d concurrent misses, want 1", loads, concurrency)
+	}
+}
+
+func TestInvalidateDeletesKeysUnderPrefix(t *testing.T) {
+	c := newTestCache(t)
+	ctx := context.Background()
+
+	loader := func() ([]byte, error) { return []byte("value"), nil }
+	if _, err := c.GetOrLoad(ctx, Key("tip"), time.Minute, loader); err != nil {
+		t.Fatalf("GetOrLoad(tip) error = %v", err)
+	}
+	if _, err := c.GetOrLoad(ctx, Key("tip", "extra"), time.Minute, loader); err != nil {
+		t.Fatalf("GetOrLoad(tip:extra) error = %v", err)
+	}
+	if _, err := c.GetOrLoad(ctx, Key("block", "abc"), time.Minute, loader); err != nil {
+		t.Fatalf("GetOrLoad(block:abc) error = %v", err)
+	}
+
+	if err := c.Invalidate(ctx, "tip"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+
+	if _, err := c.rdb.Get(ctx, Key("tip")).Result(); err != redis.Nil {
+		t.Errorf("Get(tip) error = %v, want redis.Nil", err)
+	}
+	if _, err := c.rdb.Get(ctx, Key("tip", "extra")).Result(); err != redis.Nil {
+		t.Errorf("Get(tip:extra) error = %v, want redis.Nil", err)
+	}
+	if _, err := c.rdb.Get(ctx, Key("block", "abc")).Result(); err != nil {
+		t.Errorf("Get(block:abc) error = %v, want the key to survive Invalidate(\"tip\")", err)
+	}
+}
@@ -0,0 +1,137 @@
+// Package config loads typed, validated configuration for the service from
+// environment variables, replacing the ad-hoc os.Getenv calls that used to
+// live in main's init().
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// PostgresConfig holds connection and pooling settings for the cardano-db-sync
+// database.
+type PostgresConfig struct {
+	URL             string        `env:"DB_URL" required:"true"`
+	MaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS" default:"25"`
+	MaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" default:"25"`
+	ConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" default:"5m"`
+	// TLSEnabled is enforced by NewApp, which refuses to start if DB_URL's
+	// sslmode explicitly disables TLS while this is true. lib/pq has no
+	// separate TLS option; sslmode in the URL is the actual switch.
+	TLSEnabled bool `env:"DB_TLS_ENABLED" default:"false"`
+}
+
+// RedisConfig holds connection and pooling settings for the Redis cache.
+type RedisConfig struct {
+	Addr     string `env:"REDIS_ADDR" default:"localhost:6379"`
+	Username string `env:"REDIS_USERNAME" default:""`
+	Password string `env:"REDIS_PASSWORD" default:""`
+	DB       int    `env:"REDIS_DB" default:"0"`
+	PoolSize int    `env:"REDIS_POOL_SIZE" default:"10"`
+	// TLSEnabled is wired into goredis.Options.TLSConfig by NewApp.
+	TLSEnabled bool `env:"REDIS_TLS_ENABLED" default:"false"`
+}
+
+// ServerConfig holds settings for the HTTP server and its lifecycle.
+type ServerConfig struct {
+	Addr              string        `env:"SERVER_ADDR" default:":8080"`
+	ShutdownTimeout   time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" default:"15s"`
+	ReadinessInterval time.Duration `env:"READINESS_PING_INTERVAL" default:"10s"`
+	SyncLagThreshold  time.Duration `env:"SYNC_LAG_THRESHOLD" default:"5m"`
+}
+
+// EventsConfig holds settings for the chain-tip event publisher.
+type EventsConfig struct {
+	PollInterval time.Duration `env:"EVENTS_POLL_INTERVAL" default:"2s"`
+}
+
+// Config is the top-level, fully-populated configuration for the service.
+type Config struct {
+	Postgres PostgresConfig
+	Redis    RedisConfig
+	Server   ServerConfig
+	Events   EventsConfig
+}
+
+// Load reads and validates configuration from the environment, failing fast
+// with a message naming the missing variable if a required field is unset.
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	for _, section := range []interface{}{&cfg.Postgres, &cfg.Redis, &cfg.Server, &cfg.Events} {
+		if err := populate(section); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// populate fills the fields of a struct pointer from the env/default/required
+// tags declared on it.
+func populate(dst interface{}) error {
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		envKey := field.Tag.Get("env")
+		if envKey == "" {
+			continue
+		}
+
+		raw, ok := os.LookupEnv(envKey)
+		if !ok {
+			if def, hasDefault := field.Tag.Lookup("default"); hasDefault {
+				raw = def
+			} else if field.Tag.Get("required") == "true" {
+				return fmt.Errorf("config: required environment variable %q is not set", envKey)
+			}
+		}
+
+		if raw == "" {
+			continue
+		}
+
+		if err := setField(v.Field(i), raw); err != nil {
+			return fmt.Errorf("config: invalid value for %q: %w", envKey, err)
+		}
+	}
+
+	return nil
+}
+
+// setField converts raw into the field's type and assigns it.
+func setField(field reflect.Value, raw string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if field.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Package db provides HTTP middleware that injects the shared *sql.DB
+// connection into the request context, so handlers depend on an interface
+// pulled from context rather than a package-level global.
+package db
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+)
+
+type ctxKey int
+
+const connKey ctxKey = iota
+
+// Middleware attaches conn to the request context of every request it
+// handles.
+func Middleware(conn *sql.DB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), connKey, conn)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the *sql.DB stashed by Middleware, if any.
+func FromContext(ctx context.Context) (*sql.DB, bool) {
+	conn, ok := ctx.Value(connKey).(*sql.DB)
+	return conn, ok
+}
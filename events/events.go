@@ -0,0 +1,76 @@
+// Package events watches cardano-db-sync for chain-tip activity and
+// republishes it as normalized events on Redis Pub/Sub, so WebSocket
+// front-ends can fan out live updates without each one polling Postgres.
+package events
+
+import "time"
+
+// Type identifies the kind of chain event.
+type Type string
+
+// Event types published by the Publisher.
+const (
+	TypeNewBlock      Type = "new_block"
+	TypeNewTx         Type = "new_tx"
+	TypeRollback      Type = "rollback"
+	TypeEpochBoundary Type = "epoch_boundary"
+)
+
+const channelPrefix = "events:"
+
+// Channel returns the Redis Pub/Sub channel name for t.
+func Channel(t Type) string {
+	return channelPrefix + string(t)
+}
+
+// Channels returns the Pub/Sub channel names for every event type, in the
+// order a subscriber should listen on all of them.
+func Channels() []string {
+	return []string{
+		Channel(TypeNewBlock),
+		Channel(TypeNewTx),
+		Channel(TypeRollback),
+		Channel(TypeEpochBoundary),
+	}
+}
+
+// Event is a normalized chain-tip event. Addresses, PolicyIDs, and
+// StakeKeys are populated on new_tx events so subscribers can filter
+// without re-querying Postgres.
+type Event struct {
+	Type      Type      `json:"type"`
+	Time      time.Time `json:"time"`
+	BlockHash string    `json:"blockHash,omitempty"`
+	BlockNo   int64     `json:"blockNo,omitempty"`
+	EpochNo   int64     `json:"epochNo,omitempty"`
+	TxHash    string    `json:"txHash,omitempty"`
+	Addresses []string  `json:"addresses,omitempty"`
+	PolicyIDs []string  `json:"policyIds,omitempty"`
+	StakeKeys []string  `json:"stakeKeys,omitempty"`
+}
+
+// Matches reports whether the event is relevant to a subscriber filtering
+// on the given address, policy ID, and stake key (each optional; an empty
+// string is a wildcard for that dimension). An event with no holders for a
+// dimension never matches a non-wildcard filter on it.
+func (e Event) Matches(address, policyID, stakeKey string) bool {
+	if address != "" && !contains(e.Addresses, address) {
+		return false
+	}
+	if policyID != "" && !contains(e.PolicyIDs, policyID) {
+		return false
+	}
+	if stakeKey != "" && !contains(e.StakeKeys, stakeKey) {
+		return false
+	}
+	return true
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, v := range haystack {
+		if v == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,281 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/36thchambersoftware/cardano-db-sync-api/cache"
+)
+
+// Publisher polls cardano-db-sync's tip for new blocks, transactions,
+// rollbacks, and epoch boundaries, and republishes each as an Event on
+// Redis Pub/Sub.
+type Publisher struct {
+	conn  *sql.DB
+	rdb   *goredis.Client
+	cache *cache.Cache
+
+	lastBlockID int64
+	lastEpochNo int64
+	seeded      bool
+}
+
+// NewPublisher returns a Publisher that watches conn, publishes to rdb, and
+// invalidates c on rollback.
+func NewPublisher(conn *sql.DB, rdb *goredis.Client, c *cache.Cache) *Publisher {
+	return &Publisher{conn: conn, rdb: rdb, cache: c}
+}
+
+// Run polls at interval until ctx is canceled. A poll error is logged and
+// the loop continues on the next tick rather than exiting - a transient DB
+// or Redis blip shouldn't permanently kill the event stream for the life
+// of the process.
+func (p *Publisher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := p.poll(ctx); err != nil {
+				log.Printf("events: poll: %v", err)
+			}
+		}
+	}
+}
+
+// blockRow is a row of the cardano-db-sync `block` table.
+type blockRow struct {
+	id      int64
+	hash    string
+	blockNo int64
+	epochNo int64
+	time    time.Time
+}
+
+// poll checks the current tip against what was last seen and publishes any
+// new_block, new_tx, rollback, or epoch_boundary events that result. When
+// the tip has advanced by more than one block since the last poll, every
+// intermediate block is published in order so none are skipped.
+func (p *Publisher) poll(ctx context.Context) error {
+	tip, err := p.queryBlock(ctx, `
+		SELECT block.id, encode(block.hash, 'hex'), block.block_no, block.epoch_no, block.time
+		FROM block
+		ORDER BY block.id DESC
+		LIMIT 1
+	`)
+	if err != nil {
+		return fmt.Errorf("query tip: %w", err)
+	}
+
+	if !p.seeded {
+		p.lastBlockID = tip.id
+		p.lastEpochNo = tip.epochNo
+		p.seeded = true
+		return nil
+	}
+
+	if tip.id == p.lastBlockID {
+		return nil
+	}
+
+	if tip.id < p.lastBlockID {
+		// A reorg has replaced the chain from p.lastBlockID down; a single
+		// rollback event carrying the new tip is enough for subscribers to
+		// re-sync from, so we don't attempt to enumerate the discarded
+		// blocks. The same reorg can have orphaned cached reads, so flush
+		// every cached query class before publishing it.
+		if err := p.invalidateCache(ctx); err != nil {
+			return fmt.Errorf("invalidate cache on rollback: %w", err)
+		}
+
+		p.lastBlockID = tip.id
+		p.lastEpochNo = tip.epochNo
+		return p.publish(ctx, Event{Type: TypeRollback, Time: time.Now(), BlockHash: tip.hash, BlockNo: tip.blockNo, EpochNo: tip.epochNo})
+	}
+
+	rows, err := p.conn.QueryContext(ctx, `
+		SELECT block.id, encode(block.hash, 'hex'), block.block_no, block.epoch_no, block.time
+		FROM block
+		WHERE block.id > $1 AND block.id <= $2
+		ORDER BY block.id ASC
+	`, p.lastBlockID, tip.id)
+	if err != nil {
+		return fmt.Errorf("query new blocks: %w", err)
+	}
+
+	var blocks []blockRow
+	for rows.Next() {
+		var b blockRow
+		if err := rows.Scan(&b.id, &b.hash, &b.blockNo, &b.epochNo, &b.time); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan block: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return fmt.Errorf("iterate new blocks: %w", err)
+	}
+	rows.Close()
+
+	for _, b := range blocks {
+		if err := p.publishBlock(ctx, b); err != nil {
+			return err
+		}
+		p.lastBlockID = b.id
+	}
+
+	return nil
+}
+
+// publishBlock emits new_block, its transactions, and an epoch_boundary
+// event if b crosses into a new epoch, then advances p.lastEpochNo.
+func (p *Publisher) publishBlock(ctx context.Context, b blockRow) error {
+	if err := p.publish(ctx, Event{Type: TypeNewBlock, Time: b.time, BlockHash: b.hash, BlockNo: b.blockNo, EpochNo: b.epochNo}); err != nil {
+		return err
+	}
+
+	if err := p.publishTxs(ctx, b.id, b.time); err != nil {
+		return err
+	}
+
+	if b.epochNo != p.lastEpochNo {
+		if err := p.publish(ctx, Event{Type: TypeEpochBoundary, Time: b.time, EpochNo: b.epochNo}); err != nil {
+			return err
+		}
+		p.lastEpochNo = b.epochNo
+	}
+
+	return nil
+}
+
+func (p *Publisher) queryBlock(ctx context.Context, query string, args ...interface{}) (blockRow, error) {
+	var b blockRow
+	row := p.conn.QueryRowContext(ctx, query, args...)
+	err := row.Scan(&b.id, &b.hash, &b.blockNo, &b.epochNo, &b.time)
+	return b, err
+}
+
+// publishTxs emits a new_tx event, annotated with the addresses, policy
+// IDs, and stake keys it touches, for every transaction in blockID.
+func (p *Publisher) publishTxs(ctx context.Context, blockID int64, blockTime time.Time) error {
+	rows, err := p.conn.QueryContext(ctx, `
+		SELECT tx.id, encode(tx.hash, 'hex')
+		FROM tx
+		WHERE tx.block_id = $1
+	`, blockID)
+	if err != nil {
+		return fmt.Errorf("query txs: %w", err)
+	}
+	defer rows.Close()
+
+	type txRow struct {
+		id   int64
+		hash string
+	}
+	var txs []txRow
+	for rows.Next() {
+		var t txRow
+		if err := rows.Scan(&t.id, &t.hash); err != nil {
+			return fmt.Errorf("scan tx: %w", err)
+		}
+		txs = append(txs, t)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate txs: %w", err)
+	}
+
+	for _, t := range txs {
+		addresses, err := p.queryStrings(ctx, `SELECT DISTINCT tx_out.address FROM tx_out WHERE tx_out.tx_id = $1`, t.id)
+		if err != nil {
+			return fmt.Errorf("query addresses for tx %s: %w", t.hash, err)
+		}
+
+		policyIDs, err := p.queryStrings(ctx, `
+			SELECT DISTINCT encode(ma.policy, 'hex')
+			FROM ma_tx_out mto
+			JOIN multi_asset ma ON ma.id = mto.ident
+			JOIN tx_out ON tx_out.id = mto.tx_out_id
+			WHERE tx_out.tx_id = $1
+		`, t.id)
+		if err != nil {
+			return fmt.Errorf("query policy ids for tx %s: %w", t.hash, err)
+		}
+
+		stakeKeys, err := p.queryStrings(ctx, `
+			SELECT DISTINCT sa.view
+			FROM tx_out
+			JOIN stake_address sa ON sa.id = tx_out.stake_address_id
+			WHERE tx_out.tx_id = $1
+		`, t.id)
+		if err != nil {
+			return fmt.Errorf("query stake keys for tx %s: %w", t.hash, err)
+		}
+
+		event := Event{
+			Type:      TypeNewTx,
+			Time:      blockTime,
+			TxHash:    t.hash,
+			Addresses: addresses,
+			PolicyIDs: policyIDs,
+			StakeKeys: stakeKeys,
+		}
+		if err := p.publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Publisher) queryStrings(ctx context.Context, query string, args ...interface{}) ([]string, error) {
+	rows, err := p.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+
+	return values, rows.Err()
+}
+
+// invalidateCache flushes every cached query class, since a rollback can
+// make cached reads for any of them stale.
+func (p *Publisher) invalidateCache(ctx context.Context) error {
+	for _, prefix := range []string{"tip", "block", "tx", "address_utxos", "asset", "epoch_params"} {
+		if err := p.cache.Invalidate(ctx, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	if err := p.rdb.Publish(ctx, Channel(event.Type), data).Err(); err != nil {
+		return fmt.Errorf("publish %s: %w", event.Type, err)
+	}
+
+	return nil
+}
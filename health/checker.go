@@ -0,0 +1,61 @@
+// Package health tracks liveness and readiness state for the service so
+// that HTTP probe handlers and the background checker that updates them
+// don't need to share package-level globals.
+package health
+
+import "sync"
+
+// Status is a point-in-time snapshot of readiness.
+type Status struct {
+	DBUp                bool
+	RedisUp             bool
+	SyncWithinThreshold bool
+}
+
+// Ready reports whether the service should be considered ready to serve
+// traffic.
+func (s Status) Ready() bool {
+	return s.DBUp && s.RedisUp && s.SyncWithinThreshold
+}
+
+// Checker holds the current readiness status, updated by a background
+// goroutine and read by the /readyz handler.
+type Checker struct {
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewChecker returns a Checker that starts out not ready, so /readyz fails
+// until the first successful probe.
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// SetDB records the outcome of the most recent Postgres ping.
+func (c *Checker) SetDB(up bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.DBUp = up
+}
+
+// SetRedis records the outcome of the most recent Redis ping.
+func (c *Checker) SetRedis(up bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.RedisUp = up
+}
+
+// SetSyncWithinThreshold records whether cardano-db-sync's chain tip is
+// within the configured lag threshold.
+func (c *Checker) SetSyncWithinThreshold(withinThreshold bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.SyncWithinThreshold = withinThreshold
+}
+
+// Snapshot returns the current status.
+func (c *Checker) Snapshot() Status {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.status
+}
@@ -0,0 +1,32 @@
+// Package redis provides HTTP middleware that injects the shared Redis
+// client into the request context, so handlers depend on an interface
+// pulled from context rather than a package-level global.
+package redis
+
+import (
+	"context"
+	"net/http"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+type ctxKey int
+
+const clientKey ctxKey = iota
+
+// Middleware attaches rc to the request context of every request it
+// handles.
+func Middleware(rc *goredis.Client) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientKey, rc)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// FromContext returns the *goredis.Client stashed by Middleware, if any.
+func FromContext(ctx context.Context) (*goredis.Client, bool) {
+	rc, ok := ctx.Value(clientKey).(*goredis.Client)
+	return rc, ok
+}
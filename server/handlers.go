@@ -0,0 +1,289 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/36thchambersoftware/cardano-db-sync-api/cache"
+	"github.com/36thchambersoftware/cardano-db-sync-api/db"
+	"github.com/36thchambersoftware/cardano-db-sync-api/health"
+)
+
+var errNoDB = errors.New("server: no *sql.DB in request context")
+var errNoRedis = errors.New("server: no *redis.Client in request context")
+var errNoCache = errors.New("server: no *cache.Cache in request context")
+
+// handleHealthz reports liveness: the process is up and serving.
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz reports readiness: DB and Redis are reachable and sync lag
+// is within threshold.
+func handleReadyz(checker *health.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := checker.Snapshot()
+		if !status.Ready() {
+			writeJSON(w, http.StatusServiceUnavailable, status)
+			return
+		}
+		writeJSON(w, http.StatusOK, status)
+	}
+}
+
+// writeJSON writes v as the JSON response body, or a 500 if encoding fails.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeError writes an error as a JSON body with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeLoadError reports a lookup-by-key loader error: sql.ErrNoRows means
+// the key doesn't exist (404), anything else is a transient DB/connection
+// failure the client should see as a 5xx, not a false negative.
+func writeLoadError(w http.ResponseWriter, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, http.StatusInternalServerError, err)
+}
+
+func handleTip(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	var tip Tip
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("tip"), cache.TTLTip, &tip, func() (interface{}, error) {
+		var t Tip
+		row := conn.QueryRowContext(r.Context(), `
+			SELECT block.block_no, block.slot_no, encode(block.hash, 'hex'), block.epoch_no, block.time
+			FROM block
+			ORDER BY block.id DESC
+			LIMIT 1
+		`)
+		if err := row.Scan(&t.BlockNo, &t.SlotNo, &t.Hash, &t.EpochNo, &t.SyncedAt); err != nil {
+			return nil, err
+		}
+		return t, nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tip)
+}
+
+func handleBlock(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	hash := chi.URLParam(r, "hash")
+
+	var block Block
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("block", hash), cache.TTLHistory, &block, func() (interface{}, error) {
+		var b Block
+		row := conn.QueryRowContext(r.Context(), `
+			SELECT encode(block.hash, 'hex'), block.block_no, block.slot_no, block.epoch_no, block.tx_count, block.time
+			FROM block
+			WHERE block.hash = decode($1, 'hex')
+		`, hash)
+		if err := row.Scan(&b.Hash, &b.BlockNo, &b.SlotNo, &b.EpochNo, &b.TxCount, &b.Time); err != nil {
+			return nil, err
+		}
+		return b, nil
+	})
+	if err != nil {
+		writeLoadError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, block)
+}
+
+func handleTx(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	hash := chi.URLParam(r, "hash")
+
+	var tx Tx
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("tx", hash), cache.TTLHistory, &tx, func() (interface{}, error) {
+		var t Tx
+		row := conn.QueryRowContext(r.Context(), `
+			SELECT encode(tx.hash, 'hex'), encode(block.hash, 'hex'), tx.block_index, tx.fee, tx.out_sum
+			FROM tx
+			JOIN block ON block.id = tx.block_id
+			WHERE tx.hash = decode($1, 'hex')
+		`, hash)
+		if err := row.Scan(&t.Hash, &t.BlockHash, &t.BlockIndex, &t.Fee, &t.OutSum); err != nil {
+			return nil, err
+		}
+		return t, nil
+	})
+	if err != nil {
+		writeLoadError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tx)
+}
+
+func handleAddressUTxOs(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	address := chi.URLParam(r, "address")
+
+	var utxos []UTxO
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("address_utxos", address), cache.TTLUTxO, &utxos, func() (interface{}, error) {
+		rows, err := conn.QueryContext(r.Context(), `
+			SELECT encode(tx.hash, 'hex'), tx_out.index, tx_out.value
+			FROM tx_out
+			JOIN tx ON tx.id = tx_out.tx_id
+			LEFT JOIN tx_in ON tx_in.tx_out_id = tx.id AND tx_in.tx_out_index = tx_out.index
+			WHERE tx_out.address = $1 AND tx_in.tx_in_id IS NULL
+		`, address)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		result := []UTxO{}
+		for rows.Next() {
+			var u UTxO
+			if err := rows.Scan(&u.TxHash, &u.Index, &u.Value); err != nil {
+				return nil, err
+			}
+			result = append(result, u)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, utxos)
+}
+
+func handleAsset(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	policyID := chi.URLParam(r, "policyId")
+	assetName := chi.URLParam(r, "assetName")
+
+	var asset Asset
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("asset", policyID, assetName), cache.TTLUTxO, &asset, func() (interface{}, error) {
+		var a Asset
+		row := conn.QueryRowContext(r.Context(), `
+			SELECT encode(ma.policy, 'hex'), encode(ma.name, 'hex'), ma.fingerprint, SUM(mto.quantity)
+			FROM multi_asset ma
+			JOIN ma_tx_out mto ON mto.ident = ma.id
+			WHERE ma.policy = decode($1, 'hex') AND ma.name = decode($2, 'hex')
+			GROUP BY ma.policy, ma.name, ma.fingerprint
+		`, policyID, assetName)
+		if err := row.Scan(&a.PolicyID, &a.AssetName, &a.Fingerprint, &a.Quantity); err != nil {
+			return nil, err
+		}
+		return a, nil
+	})
+	if err != nil {
+		writeLoadError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, asset)
+}
+
+func handleEpochParams(w http.ResponseWriter, r *http.Request) {
+	conn, ok := db.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoDB)
+		return
+	}
+	c, ok := cache.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoCache)
+		return
+	}
+
+	number := chi.URLParam(r, "number")
+
+	var params EpochParams
+	err := c.GetOrLoadJSON(r.Context(), cache.Key("epoch_params", number), cache.TTLHistory, &params, func() (interface{}, error) {
+		var p EpochParams
+		row := conn.QueryRowContext(r.Context(), `
+			SELECT epoch_no, min_fee_a, min_fee_b, max_block_size, max_tx_size, key_deposit, pool_deposit
+			FROM epoch_param
+			WHERE epoch_no = $1
+		`, number)
+		if err := row.Scan(&p.EpochNo, &p.MinFeeA, &p.MinFeeB, &p.MaxBlockSize, &p.MaxTxSize, &p.KeyDeposit, &p.PoolDeposit); err != nil {
+			return nil, err
+		}
+		return p, nil
+	})
+	if err != nil {
+		writeLoadError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, params)
+}
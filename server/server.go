@@ -0,0 +1,54 @@
+// Package server exposes the cardano-db-sync-api's HTTP endpoints, wiring
+// the shared Postgres and Redis connections into the request context via
+// middleware instead of package-level globals.
+package server
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/36thchambersoftware/cardano-db-sync-api/cache"
+	dbmw "github.com/36thchambersoftware/cardano-db-sync-api/db"
+	"github.com/36thchambersoftware/cardano-db-sync-api/health"
+	redismw "github.com/36thchambersoftware/cardano-db-sync-api/redis"
+)
+
+// New builds the router for the service, injecting conn, rdb, and c into
+// every request via middleware. checker backs the /healthz and /readyz
+// probes.
+func New(conn *sql.DB, rdb *goredis.Client, c *cache.Cache, checker *health.Checker) http.Handler {
+	r := chi.NewRouter()
+
+	r.Use(chimw.RequestID)
+	r.Use(chimw.Logger)
+	r.Use(chimw.Recoverer)
+	r.Use(dbmw.Middleware(conn))
+	r.Use(redismw.Middleware(rdb))
+	r.Use(cache.Middleware(c))
+
+	// Request/response routes get a request-lifetime deadline. /events is
+	// a long-lived WebSocket stream and is deliberately excluded from this
+	// group so a 30s timeout doesn't tear down every client connection.
+	r.Group(func(r chi.Router) {
+		r.Use(chimw.Timeout(30 * time.Second))
+
+		r.Get("/healthz", handleHealthz)
+		r.Get("/readyz", handleReadyz(checker))
+
+		r.Get("/tip", handleTip)
+		r.Get("/blocks/{hash}", handleBlock)
+		r.Get("/txs/{hash}", handleTx)
+		r.Get("/addresses/{address}/utxos", handleAddressUTxOs)
+		r.Get("/assets/{policyId}/{assetName}", handleAsset)
+		r.Get("/epochs/{number}/parameters", handleEpochParams)
+	})
+
+	r.Get("/events", handleEvents)
+
+	return r
+}
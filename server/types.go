@@ -0,0 +1,55 @@
+package server
+
+// Tip is the current chain tip as seen by cardano-db-sync.
+type Tip struct {
+	BlockNo  int64  `json:"blockNo"`
+	SlotNo   int64  `json:"slotNo"`
+	Hash     string `json:"hash"`
+	EpochNo  int64  `json:"epochNo"`
+	SyncedAt string `json:"syncedAt"`
+}
+
+// Block is a subset of the cardano-db-sync `block` table.
+type Block struct {
+	Hash    string `json:"hash"`
+	BlockNo int64  `json:"blockNo"`
+	SlotNo  int64  `json:"slotNo"`
+	EpochNo int64  `json:"epochNo"`
+	TxCount int64  `json:"txCount"`
+	Time    string `json:"time"`
+}
+
+// Tx is a subset of the cardano-db-sync `tx` table.
+type Tx struct {
+	Hash       string `json:"hash"`
+	BlockHash  string `json:"blockHash"`
+	BlockIndex int64  `json:"blockIndex"`
+	Fee        string `json:"fee"`
+	OutSum     string `json:"outSum"`
+}
+
+// UTxO is an unspent transaction output owned by an address.
+type UTxO struct {
+	TxHash string `json:"txHash"`
+	Index  int64  `json:"index"`
+	Value  string `json:"value"`
+}
+
+// Asset describes a native asset's on-chain identity and supply.
+type Asset struct {
+	PolicyID    string `json:"policyId"`
+	AssetName   string `json:"assetName"`
+	Fingerprint string `json:"fingerprint"`
+	Quantity    string `json:"quantity"`
+}
+
+// EpochParams is a subset of the cardano-db-sync `epoch_param` table.
+type EpochParams struct {
+	EpochNo      int64  `json:"epochNo"`
+	MinFeeA      int64  `json:"minFeeA"`
+	MinFeeB      int64  `json:"minFeeB"`
+	MaxBlockSize int64  `json:"maxBlockSize"`
+	MaxTxSize    int64  `json:"maxTxSize"`
+	KeyDeposit   string `json:"keyDeposit"`
+	PoolDeposit  string `json:"poolDeposit"`
+}
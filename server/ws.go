@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/36thchambersoftware/cardano-db-sync-api/events"
+	"github.com/36thchambersoftware/cardano-db-sync-api/redis"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// Same-origin is not enforceable generically here; front-ends behind a
+	// load balancer are expected to terminate and authorize before this.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// handleEvents upgrades to a WebSocket and streams chain-tip events,
+// filtered by the optional address, policyId, and stakeKey query params.
+func handleEvents(w http.ResponseWriter, r *http.Request) {
+	rdb, ok := redis.FromContext(r.Context())
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errNoRedis)
+		return
+	}
+
+	address := r.URL.Query().Get("address")
+	policyID := r.URL.Query().Get("policyId")
+	stakeKey := r.URL.Query().Get("stakeKey")
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("server: websocket upgrade: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// The subscription lives as long as the WebSocket connection does, not
+	// as long as the originating HTTP request - the request's context
+	// carries a handler-scoped deadline (see the Timeout middleware
+	// applied to the rest of the API) that would otherwise tear down long-
+	// lived streams after 30s.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// We never read client-initiated messages, but ReadMessage still needs
+	// to run so gorilla processes control frames and so we notice the
+	// client going away.
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	sub := rdb.Subscribe(ctx, events.Channels()...)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var event events.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("server: decode event: %v", err)
+				continue
+			}
+
+			if !event.Matches(address, policyID, stakeKey) {
+				continue
+			}
+
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}